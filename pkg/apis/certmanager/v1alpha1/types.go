@@ -0,0 +1,293 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Certificate is a type to represent a Certificate request to be issued by
+// one of the configured Issuers.
+type Certificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CertificateSpec   `json:"spec,omitempty"`
+	Status CertificateStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CertificateList is a list of Certificates
+type CertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Certificate `json:"items"`
+}
+
+type CertificateSpec struct {
+	// Domains is a list of domains to obtain a certificate for
+	Domains []string `json:"domains"`
+
+	// Issuer is the name of the Issuer or ClusterIssuer resource in the
+	// Certificate's namespace that will be used to issue the certificate
+	Issuer string `json:"issuer"`
+
+	// SecretName is the name of the secret resource to store this secret in
+	SecretName string `json:"secretName"`
+
+	// RenewBefore is the amount of time before the currently issued
+	// certificate's "notAfter" time that the certificate should be
+	// renewed. If this is not set, a default of 30 days will be used.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// KeyAlgorithm is the private key algorithm to use when generating a
+	// private key for this Certificate. If not set, RSA will be used.
+	// +optional
+	KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+
+	// KeySize is the key bit size of the corresponding private key for this
+	// Certificate. If not set, a default of 2048 (RSA) or 256 (ECDSA) will
+	// be used.
+	// +optional
+	KeySize int `json:"keySize,omitempty"`
+
+	// ReusePrivateKey, if true, will cause the controller to re-use the
+	// private key currently stored in the target Secret (if one exists)
+	// when issuing or renewing this Certificate, rather than generating a
+	// new one each time.
+	// +optional
+	ReusePrivateKey bool `json:"reusePrivateKey,omitempty"`
+
+	// ACME contains configuration specific to certificates issued by an ACME
+	// issuer, such as the challenge solver to use for each domain.
+	// +optional
+	ACME *ACMECertificateConfig `json:"acme,omitempty"`
+
+	// IPAddresses is a list of IP subject alternative names to be set on the
+	// Certificate.
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// URIs is a list of URI subject alternative names to be set on the
+	// Certificate.
+	// +optional
+	URIs []string `json:"uris,omitempty"`
+
+	// EmailAddresses is a list of email subject alternative names to be set
+	// on the Certificate.
+	// +optional
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// CommonName is the common name to be set on the Certificate.
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// Usages is the set of key usages and extended key usages the issued
+	// certificate should have. If not set, a default appropriate to a TLS
+	// server certificate will be used.
+	// +optional
+	Usages []KeyUsage `json:"usages,omitempty"`
+
+	// IsCA will mark the resulting certificate as valid for signing, by
+	// setting the basic constraints CA field to true.
+	// +optional
+	IsCA bool `json:"isCA,omitempty"`
+}
+
+// KeyUsage specifies a key usage or extended key usage that should appear
+// on an issued certificate.
+type KeyUsage string
+
+const (
+	UsageSigning          KeyUsage = "signing"
+	UsageDigitalSignature KeyUsage = "digital signature"
+	UsageKeyEncipherment  KeyUsage = "key encipherment"
+	UsageServerAuth       KeyUsage = "server auth"
+	UsageClientAuth       KeyUsage = "client auth"
+	UsageCodeSigning      KeyUsage = "code signing"
+	UsageEmailProtection  KeyUsage = "email protection"
+	UsageCertSign         KeyUsage = "cert sign"
+)
+
+// ACMECertificateConfig contains the configuration for an ACME certificate,
+// namely the challenge solver to use to validate ownership of each domain.
+type ACMECertificateConfig struct {
+	Config []ACMECertificateDomainConfig `json:"config"`
+}
+
+// ACMECertificateDomainConfig configures the challenge solver to be used for
+// a given set of domains on a Certificate.
+type ACMECertificateDomainConfig struct {
+	Domains []string `json:"domains"`
+
+	ACMECertificateDomainSolverConfig `json:",inline"`
+}
+
+type ACMECertificateDomainSolverConfig struct {
+	// +optional
+	HTTP01 *ACMECertificateHTTP01Config `json:"http01,omitempty"`
+	// +optional
+	DNS01 *ACMECertificateDNS01Config `json:"dns01,omitempty"`
+	// +optional
+	TLSALPN01 *ACMECertificateTLSALPN01Config `json:"tlsalpn01,omitempty"`
+}
+
+// ACMECertificateHTTP01Config configures the http-01 challenge solver
+type ACMECertificateHTTP01Config struct {
+}
+
+// ACMECertificateDNS01Config configures the dns-01 challenge solver
+type ACMECertificateDNS01Config struct {
+	Provider string `json:"provider"`
+}
+
+// ACMECertificateTLSALPN01Config configures the tls-alpn-01 challenge
+// solver. It requires no further configuration: the solver self-signs a
+// certificate for the domain being validated and serves it from an
+// in-cluster Pod/Service on port 443.
+type ACMECertificateTLSALPN01Config struct {
+}
+
+// KeyAlgorithm is the type of private key algorithm to use when generating a
+// private key for a Certificate.
+type KeyAlgorithm string
+
+const (
+	RSAKeyAlgorithm   KeyAlgorithm = "rsa"
+	ECDSAKeyAlgorithm KeyAlgorithm = "ecdsa"
+)
+
+type CertificateStatus struct {
+	// Conditions is a set of conditions representing the observed state of
+	// the Certificate, following the standard Kubernetes condition
+	// convention.
+	// +optional
+	Conditions []CertificateCondition `json:"conditions,omitempty"`
+
+	// NotAfter is the expiry date of the currently issued certificate, as
+	// recorded the last time the controller issued or renewed it.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// NotBefore is the start of the validity period of the currently issued
+	// certificate.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// LastFailureTime is set each time an issuance or renewal attempt for
+	// this Certificate fails, and is used to back off from repeatedly
+	// hammering an issuer with a broken configuration.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// FailedIssuanceAttempts is the number of consecutive issuance or
+	// renewal attempts that have failed since the last success. It is reset
+	// to zero on a successful issuance and used to grow the backoff applied
+	// between retries.
+	// +optional
+	FailedIssuanceAttempts int `json:"failedIssuanceAttempts,omitempty"`
+}
+
+// CertificateConditionType represents a Certificate condition value.
+type CertificateConditionType string
+
+const (
+	// CertificateConditionReady indicates that the Certificate has a
+	// current, valid certificate available in its target Secret.
+	CertificateConditionReady CertificateConditionType = "Ready"
+
+	// CertificateConditionIssuing indicates that the Certificate is
+	// currently being issued or renewed.
+	CertificateConditionIssuing CertificateConditionType = "Issuing"
+
+	// CertificateConditionValidationFailed indicates that the last
+	// issuance attempt failed validation with the configured issuer.
+	CertificateConditionValidationFailed CertificateConditionType = "ValidationFailed"
+)
+
+// CertificateCondition contains condition information for a Certificate,
+// following the standard Kubernetes condition convention.
+type CertificateCondition struct {
+	// Type of the condition, currently ('Ready', 'Issuing', 'ValidationFailed').
+	Type CertificateConditionType `json:"type"`
+
+	// Status of the condition, one of ('True', 'False', 'Unknown').
+	Status ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief machine readable explanation for the condition's
+	// last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ConditionStatus represents a condition's status.
+type ConditionStatus string
+
+const (
+	ConditionTrue    ConditionStatus = "True"
+	ConditionFalse   ConditionStatus = "False"
+	ConditionUnknown ConditionStatus = "Unknown"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Issuer represents a certificate signing authority which can be used to
+// issue certificates
+type Issuer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IssuerSpec   `json:"spec,omitempty"`
+	Status IssuerStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IssuerList is a list of Issuers
+type IssuerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Issuer `json:"items"`
+}
+
+type IssuerSpec struct {
+	IssuerConfig `json:",inline"`
+}
+
+type IssuerConfig struct {
+	ACME *ACMEIssuer `json:"acme,omitempty"`
+	CA   *CAIssuer   `json:"ca,omitempty"`
+}
+
+// ACMEIssuer contains the specification for an ACME issuer
+type ACMEIssuer struct {
+	Email  string `json:"email"`
+	Server string `json:"server"`
+}
+
+// CAIssuer contains the specification for a signing key pair based issuer
+type CAIssuer struct {
+	SecretName string `json:"secretName"`
+}
+
+type IssuerStatus struct {
+	// Ready indicates whether the Issuer has successfully verified its
+	// signing key pair and is ready to issue certificates
+	Ready bool `json:"ready"`
+}
@@ -0,0 +1,322 @@
+// +build !ignore_autogenerated
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto copies the receiver into out.
+func (in *Certificate) DeepCopyInto(out *Certificate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Certificate.
+func (in *Certificate) DeepCopy() *Certificate {
+	if in == nil {
+		return nil
+	}
+	out := new(Certificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new Certificate.
+func (in *Certificate) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *CertificateList) DeepCopyInto(out *CertificateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		out.Items = make([]Certificate, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateList.
+func (in *CertificateList) DeepCopy() *CertificateList {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new CertificateList.
+func (in *CertificateList) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
+	*out = *in
+	if in.Domains != nil {
+		out.Domains = make([]string, len(in.Domains))
+		copy(out.Domains, in.Domains)
+	}
+	if in.RenewBefore != nil {
+		out.RenewBefore = new(metav1.Duration)
+		*out.RenewBefore = *in.RenewBefore
+	}
+	if in.ACME != nil {
+		out.ACME = new(ACMECertificateConfig)
+		in.ACME.DeepCopyInto(out.ACME)
+	}
+	if in.IPAddresses != nil {
+		out.IPAddresses = make([]string, len(in.IPAddresses))
+		copy(out.IPAddresses, in.IPAddresses)
+	}
+	if in.URIs != nil {
+		out.URIs = make([]string, len(in.URIs))
+		copy(out.URIs, in.URIs)
+	}
+	if in.EmailAddresses != nil {
+		out.EmailAddresses = make([]string, len(in.EmailAddresses))
+		copy(out.EmailAddresses, in.EmailAddresses)
+	}
+	if in.Usages != nil {
+		out.Usages = make([]KeyUsage, len(in.Usages))
+		copy(out.Usages, in.Usages)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateSpec.
+func (in *CertificateSpec) DeepCopy() *CertificateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ACMECertificateConfig) DeepCopyInto(out *ACMECertificateConfig) {
+	*out = *in
+	if in.Config != nil {
+		out.Config = make([]ACMECertificateDomainConfig, len(in.Config))
+		for i := range in.Config {
+			in.Config[i].DeepCopyInto(&out.Config[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMECertificateConfig.
+func (in *ACMECertificateConfig) DeepCopy() *ACMECertificateConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMECertificateConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ACMECertificateDomainConfig) DeepCopyInto(out *ACMECertificateDomainConfig) {
+	*out = *in
+	if in.Domains != nil {
+		out.Domains = make([]string, len(in.Domains))
+		copy(out.Domains, in.Domains)
+	}
+	in.ACMECertificateDomainSolverConfig.DeepCopyInto(&out.ACMECertificateDomainSolverConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMECertificateDomainConfig.
+func (in *ACMECertificateDomainConfig) DeepCopy() *ACMECertificateDomainConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMECertificateDomainConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ACMECertificateDomainSolverConfig) DeepCopyInto(out *ACMECertificateDomainSolverConfig) {
+	*out = *in
+	if in.HTTP01 != nil {
+		out.HTTP01 = new(ACMECertificateHTTP01Config)
+		*out.HTTP01 = *in.HTTP01
+	}
+	if in.DNS01 != nil {
+		out.DNS01 = new(ACMECertificateDNS01Config)
+		*out.DNS01 = *in.DNS01
+	}
+	if in.TLSALPN01 != nil {
+		out.TLSALPN01 = new(ACMECertificateTLSALPN01Config)
+		*out.TLSALPN01 = *in.TLSALPN01
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMECertificateDomainSolverConfig.
+func (in *ACMECertificateDomainSolverConfig) DeepCopy() *ACMECertificateDomainSolverConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMECertificateDomainSolverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		out.Conditions = make([]CertificateCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&out.Conditions[i])
+		}
+	}
+	if in.NotAfter != nil {
+		out.NotAfter = in.NotAfter.DeepCopy()
+	}
+	if in.NotBefore != nil {
+		out.NotBefore = in.NotBefore.DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		out.LastFailureTime = in.LastFailureTime.DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *CertificateCondition) DeepCopyInto(out *CertificateCondition) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		out.LastTransitionTime = in.LastTransitionTime.DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateCondition.
+func (in *CertificateCondition) DeepCopy() *CertificateCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *Issuer) DeepCopyInto(out *Issuer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Issuer.
+func (in *Issuer) DeepCopy() *Issuer {
+	if in == nil {
+		return nil
+	}
+	out := new(Issuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new Issuer.
+func (in *Issuer) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *IssuerList) DeepCopyInto(out *IssuerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		out.Items = make([]Issuer, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerList.
+func (in *IssuerList) DeepCopy() *IssuerList {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new IssuerList.
+func (in *IssuerList) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *IssuerSpec) DeepCopyInto(out *IssuerSpec) {
+	*out = *in
+	in.IssuerConfig.DeepCopyInto(&out.IssuerConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerSpec.
+func (in *IssuerSpec) DeepCopy() *IssuerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *IssuerConfig) DeepCopyInto(out *IssuerConfig) {
+	*out = *in
+	if in.ACME != nil {
+		out.ACME = new(ACMEIssuer)
+		*out.ACME = *in.ACME
+	}
+	if in.CA != nil {
+		out.CA = new(CAIssuer)
+		*out.CA = *in.CA
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerConfig.
+func (in *IssuerConfig) DeepCopy() *IssuerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
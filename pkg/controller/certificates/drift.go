@@ -0,0 +1,142 @@
+package certificates
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+// certificateMatchesSpec checks whether the already-issued cert still
+// satisfies every field of crt.Spec that it's derived from. It returns true
+// if cert still matches the spec, or false along with a human readable reason
+// if cert needs to be re-issued.
+func certificateMatchesSpec(crt *v1alpha1.Certificate, cert *x509.Certificate) (ok bool, reason string) {
+	if !equalUnsorted(crt.Spec.Domains, cert.DNSNames) {
+		return false, "domains on certificate do not match domains in spec"
+	}
+
+	if !equalUnsorted(crt.Spec.IPAddresses, ipAddressesToStrings(cert.IPAddresses)) {
+		return false, "ip addresses on certificate do not match ipAddresses in spec"
+	}
+
+	if !equalUnsorted(crt.Spec.URIs, urisToStrings(cert.URIs)) {
+		return false, "uris on certificate do not match uris in spec"
+	}
+
+	if !equalUnsorted(crt.Spec.EmailAddresses, cert.EmailAddresses) {
+		return false, "email addresses on certificate do not match emailAddresses in spec"
+	}
+
+	if crt.Spec.CommonName != "" && crt.Spec.CommonName != cert.Subject.CommonName {
+		return false, "common name on certificate does not match commonName in spec"
+	}
+
+	if len(crt.Spec.Usages) > 0 && !equalUnsortedUsages(crt.Spec.Usages, usagesForCertificate(cert)) {
+		return false, "key usages on certificate do not match usages in spec"
+	}
+
+	if crt.Spec.IsCA != cert.IsCA {
+		return false, "isCA on certificate does not match isCA in spec"
+	}
+
+	if mismatch := keyAlgorithmMismatch(crt, cert); mismatch != "" {
+		return false, mismatch
+	}
+
+	return true, ""
+}
+
+func ipAddressesToStrings(ips []net.IP) []string {
+	out := make([]string, len(ips))
+	for i, ip := range ips {
+		out[i] = ip.String()
+	}
+	return out
+}
+
+func urisToStrings(uris []*url.URL) []string {
+	out := make([]string, len(uris))
+	for i, u := range uris {
+		out[i] = u.String()
+	}
+	return out
+}
+
+// usagesForCertificate derives the set of cert-manager KeyUsage values
+// implied by cert's key usage bitmask and extended key usages.
+func usagesForCertificate(cert *x509.Certificate) []v1alpha1.KeyUsage {
+	var usages []v1alpha1.KeyUsage
+
+	if cert.KeyUsage&x509.KeyUsageDigitalSignature != 0 {
+		usages = append(usages, v1alpha1.UsageDigitalSignature)
+	}
+	if cert.KeyUsage&x509.KeyUsageKeyEncipherment != 0 {
+		usages = append(usages, v1alpha1.UsageKeyEncipherment)
+	}
+	if cert.KeyUsage&x509.KeyUsageCertSign != 0 {
+		usages = append(usages, v1alpha1.UsageCertSign)
+	}
+
+	for _, eku := range cert.ExtKeyUsage {
+		switch eku {
+		case x509.ExtKeyUsageServerAuth:
+			usages = append(usages, v1alpha1.UsageServerAuth)
+		case x509.ExtKeyUsageClientAuth:
+			usages = append(usages, v1alpha1.UsageClientAuth)
+		case x509.ExtKeyUsageCodeSigning:
+			usages = append(usages, v1alpha1.UsageCodeSigning)
+		case x509.ExtKeyUsageEmailProtection:
+			usages = append(usages, v1alpha1.UsageEmailProtection)
+		}
+	}
+
+	return usages
+}
+
+func equalUnsortedUsages(s1, s2 []v1alpha1.KeyUsage) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+
+	a := make([]string, len(s1))
+	for i, u := range s1 {
+		a[i] = string(u)
+	}
+	b := make([]string, len(s2))
+	for i, u := range s2 {
+		b[i] = string(u)
+	}
+
+	sort.Strings(a)
+	sort.Strings(b)
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// keyAlgorithmMismatch checks the certificate's public key algorithm against
+// crt.Spec.KeyAlgorithm. An unset KeyAlgorithm defaults to RSA, per its doc
+// comment in types.go, so it is enforced the same way as an explicit "rsa".
+func keyAlgorithmMismatch(crt *v1alpha1.Certificate, cert *x509.Certificate) string {
+	switch crt.Spec.KeyAlgorithm {
+	case "", v1alpha1.RSAKeyAlgorithm:
+		if cert.PublicKeyAlgorithm != x509.RSA {
+			return fmt.Sprintf("certificate public key algorithm %s does not match keyAlgorithm %s in spec", cert.PublicKeyAlgorithm, crt.Spec.KeyAlgorithm)
+		}
+	case v1alpha1.ECDSAKeyAlgorithm:
+		if cert.PublicKeyAlgorithm != x509.ECDSA {
+			return fmt.Sprintf("certificate public key algorithm %s does not match keyAlgorithm %s in spec", cert.PublicKeyAlgorithm, crt.Spec.KeyAlgorithm)
+		}
+	}
+
+	return ""
+}
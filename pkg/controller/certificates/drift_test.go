@@ -0,0 +1,189 @@
+package certificates
+
+import (
+	"crypto/x509"
+	"net"
+	"testing"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha1"
+)
+
+func TestEqualUnsorted(t *testing.T) {
+	tests := []struct {
+		name   string
+		s1, s2 []string
+		want   bool
+	}{
+		{"equal in order", []string{"a", "b"}, []string{"a", "b"}, true},
+		{"equal out of order", []string{"a", "b"}, []string{"b", "a"}, true},
+		{"different lengths", []string{"a"}, []string{"a", "b"}, false},
+		{"same length different contents", []string{"a", "b"}, []string{"a", "c"}, false},
+		{"both empty", nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := equalUnsorted(tt.s1, tt.s2); got != tt.want {
+				t.Errorf("equalUnsorted(%v, %v) = %v, want %v", tt.s1, tt.s2, got, tt.want)
+			}
+		})
+	}
+}
+
+func baseCertificate() *v1alpha1.Certificate {
+	return &v1alpha1.Certificate{
+		Spec: v1alpha1.CertificateSpec{
+			Domains: []string{"example.com"},
+		},
+	}
+}
+
+func baseX509Certificate() *x509.Certificate {
+	return &x509.Certificate{
+		DNSNames:           []string{"example.com"},
+		PublicKeyAlgorithm: x509.RSA,
+	}
+}
+
+func TestCertificateMatchesSpec(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutateCrt func(*v1alpha1.Certificate)
+		mutateX   func(*x509.Certificate)
+		wantOK    bool
+	}{
+		{
+			name:   "matching certificate",
+			wantOK: true,
+		},
+		{
+			name: "domain mismatch",
+			mutateCrt: func(c *v1alpha1.Certificate) {
+				c.Spec.Domains = []string{"example.com", "www.example.com"}
+			},
+			wantOK: false,
+		},
+		{
+			name: "ip address mismatch",
+			mutateCrt: func(c *v1alpha1.Certificate) {
+				c.Spec.IPAddresses = []string{"10.0.0.1"}
+			},
+			wantOK: false,
+		},
+		{
+			name: "ip address match",
+			mutateCrt: func(c *v1alpha1.Certificate) {
+				c.Spec.IPAddresses = []string{"10.0.0.1"}
+			},
+			mutateX: func(x *x509.Certificate) {
+				x.IPAddresses = []net.IP{net.ParseIP("10.0.0.1")}
+			},
+			wantOK: true,
+		},
+		{
+			name: "uri mismatch",
+			mutateCrt: func(c *v1alpha1.Certificate) {
+				c.Spec.URIs = []string{"spiffe://cluster.local/ns/default/sa/foo"}
+			},
+			wantOK: false,
+		},
+		{
+			name: "email address mismatch",
+			mutateCrt: func(c *v1alpha1.Certificate) {
+				c.Spec.EmailAddresses = []string{"foo@example.com"}
+			},
+			wantOK: false,
+		},
+		{
+			name: "common name mismatch",
+			mutateCrt: func(c *v1alpha1.Certificate) {
+				c.Spec.CommonName = "example.com"
+			},
+			wantOK: false,
+		},
+		{
+			name: "common name match",
+			mutateCrt: func(c *v1alpha1.Certificate) {
+				c.Spec.CommonName = "example.com"
+			},
+			mutateX: func(x *x509.Certificate) {
+				x.Subject.CommonName = "example.com"
+			},
+			wantOK: true,
+		},
+		{
+			name: "usages mismatch",
+			mutateCrt: func(c *v1alpha1.Certificate) {
+				c.Spec.Usages = []v1alpha1.KeyUsage{v1alpha1.UsageServerAuth}
+			},
+			wantOK: false,
+		},
+		{
+			name: "usages match",
+			mutateCrt: func(c *v1alpha1.Certificate) {
+				c.Spec.Usages = []v1alpha1.KeyUsage{v1alpha1.UsageServerAuth, v1alpha1.UsageDigitalSignature}
+			},
+			mutateX: func(x *x509.Certificate) {
+				x.KeyUsage = x509.KeyUsageDigitalSignature
+				x.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+			},
+			wantOK: true,
+		},
+		{
+			name: "isCA mismatch",
+			mutateCrt: func(c *v1alpha1.Certificate) {
+				c.Spec.IsCA = true
+			},
+			wantOK: false,
+		},
+		{
+			name: "key algorithm mismatch",
+			mutateCrt: func(c *v1alpha1.Certificate) {
+				c.Spec.KeyAlgorithm = v1alpha1.ECDSAKeyAlgorithm
+			},
+			mutateX: func(x *x509.Certificate) {
+				x.PublicKeyAlgorithm = x509.RSA
+			},
+			wantOK: false,
+		},
+		{
+			name: "key algorithm match",
+			mutateCrt: func(c *v1alpha1.Certificate) {
+				c.Spec.KeyAlgorithm = v1alpha1.ECDSAKeyAlgorithm
+			},
+			mutateX: func(x *x509.Certificate) {
+				x.PublicKeyAlgorithm = x509.ECDSA
+			},
+			wantOK: true,
+		},
+		{
+			name: "unset key algorithm defaults to rsa and flags ecdsa cert",
+			mutateX: func(x *x509.Certificate) {
+				x.PublicKeyAlgorithm = x509.ECDSA
+			},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crt := baseCertificate()
+			cert := baseX509Certificate()
+
+			if tt.mutateCrt != nil {
+				tt.mutateCrt(crt)
+			}
+			if tt.mutateX != nil {
+				tt.mutateX(cert)
+			}
+
+			ok, reason := certificateMatchesSpec(crt, cert)
+			if ok != tt.wantOK {
+				t.Errorf("certificateMatchesSpec() = (%v, %q), want ok=%v", ok, reason, tt.wantOK)
+			}
+			if !ok && reason == "" {
+				t.Errorf("certificateMatchesSpec() returned ok=false with empty reason")
+			}
+		})
+	}
+}
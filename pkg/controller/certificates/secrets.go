@@ -0,0 +1,151 @@
+package certificates
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	api "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/controller"
+)
+
+const (
+	certificateNameAnnotation = "certmanager.k8s.io/certificate-name"
+	commonNameAnnotation      = "certmanager.k8s.io/common-name"
+	altNamesAnnotation        = "certmanager.k8s.io/alt-names"
+	issuerNameAnnotation      = "certmanager.k8s.io/issuer-name"
+
+	// caCertKey is the Secret data key the issuing CA chain is stored under.
+	caCertKey = "ca.crt"
+)
+
+// backupSecretName returns the name of the Secret used to hold the last
+// known-good copy of crt's issued certificate, so that it can be restored if
+// the primary Secret is deleted without also deleting the Certificate.
+func backupSecretName(crt *v1alpha1.Certificate) string {
+	return crt.Spec.SecretName + "-backup"
+}
+
+// saveCertificate writes the newly issued/renewed cert, key and issuing ca
+// chain for crt to its target Secret, creating it if it doesn't already
+// exist or updating it in place otherwise, and keeps a backup copy around so
+// that the Secret can be restored if it is deleted without burning an ACME
+// issuance.
+func saveCertificate(ctx *controller.Context, crt *v1alpha1.Certificate, cert, key, ca []byte) error {
+	secret, err := buildSecret(crt, crt.Spec.SecretName, cert, key, ca)
+	if err != nil {
+		return err
+	}
+
+	if err := upsertSecret(ctx, crt.Namespace, secret); err != nil {
+		return err
+	}
+
+	backup, err := buildSecret(crt, backupSecretName(crt), cert, key, ca)
+	if err != nil {
+		return err
+	}
+
+	return upsertSecret(ctx, crt.Namespace, backup)
+}
+
+// restoreCertificateFromBackup recreates crt's target Secret from its backup
+// copy, avoiding a re-issuance (and the associated rate-limit cost) when the
+// target Secret is deleted but the Certificate and its backup still exist.
+func restoreCertificateFromBackup(ctx *controller.Context, crt *v1alpha1.Certificate, backup *api.Secret) error {
+	ca := backup.Data[caCertKey]
+	leaf := bytes.TrimSuffix(backup.Data[api.TLSCertKey], ca)
+
+	secret, err := buildSecret(crt, crt.Spec.SecretName, leaf, backup.Data[api.TLSPrivateKeyKey], ca)
+	if err != nil {
+		return err
+	}
+
+	return upsertSecret(ctx, crt.Namespace, secret)
+}
+
+// buildSecret constructs the Secret that should be written for crt, setting
+// an OwnerReference back to the Certificate so the Secret is garbage
+// collected with it, and the standard cert-manager annotations.
+func buildSecret(crt *v1alpha1.Certificate, name string, cert, key, ca []byte) (*api.Secret, error) {
+	commonName, altNames, err := commonNameAndAltNames(cert)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing issued certificate: %s", err.Error())
+	}
+
+	fullChain := append(append([]byte{}, cert...), ca...)
+
+	return &api.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: crt.Namespace,
+			Annotations: map[string]string{
+				certificateNameAnnotation: crt.Name,
+				commonNameAnnotation:      commonName,
+				altNamesAnnotation:        altNames,
+				issuerNameAnnotation:      crt.Spec.Issuer,
+			},
+			OwnerReferences: []metav1.OwnerReference{certificateOwnerReference(crt)},
+		},
+		Data: map[string][]byte{
+			api.TLSCertKey:       fullChain,
+			api.TLSPrivateKeyKey: key,
+			caCertKey:            ca,
+		},
+		Type: api.SecretTypeTLS,
+	}, nil
+}
+
+func certificateOwnerReference(crt *v1alpha1.Certificate) metav1.OwnerReference {
+	blockOwnerDeletion := true
+	isController := true
+	return metav1.OwnerReference{
+		APIVersion:         v1alpha1.SchemeGroupVersion.String(),
+		Kind:               "Certificate",
+		Name:               crt.Name,
+		UID:                crt.UID,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+		Controller:         &isController,
+	}
+}
+
+func commonNameAndAltNames(certBytes []byte) (commonName, altNames string, err error) {
+	block, _ := pem.Decode(certBytes)
+	if block == nil {
+		return "", "", fmt.Errorf("failed to decode certificate PEM")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", "", err
+	}
+
+	return cert.Subject.CommonName, strings.Join(cert.DNSNames, ","), nil
+}
+
+// upsertSecret creates secret if it doesn't exist, or updates it in place
+// (preserving its resourceVersion) if it does.
+func upsertSecret(ctx *controller.Context, namespace string, secret *api.Secret) error {
+	existing, err := ctx.Client.Secrets(namespace).Get(secret.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			_, err = ctx.Client.Secrets(namespace).Create(secret)
+			return err
+		}
+		return err
+	}
+
+	existing.Annotations = secret.Annotations
+	existing.OwnerReferences = secret.OwnerReferences
+	existing.Data = secret.Data
+	existing.Type = secret.Type
+
+	_, err = ctx.Client.Secrets(namespace).Update(existing)
+	return err
+}
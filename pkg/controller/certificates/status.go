@@ -0,0 +1,147 @@
+package certificates
+
+import (
+	"crypto/x509"
+	"math"
+	"time"
+
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/controller"
+)
+
+const (
+	reasonIssued           = "Issued"
+	reasonRenewalScheduled = "RenewalScheduled"
+	reasonIssuerNotReady   = "IssuerNotReady"
+	reasonDomainMismatch   = "DomainMismatch"
+	reasonIssuanceFailed   = "IssuanceFailed"
+	reasonPrepareFailed    = "ValidationFailed"
+
+	// failureBackoffBase is the initial backoff applied after a single
+	// issuance failure.
+	failureBackoffBase = time.Minute
+	// failureBackoffMax caps the exponential backoff applied between
+	// issuance retries so a persistently broken configuration can't be
+	// retried less than once an hour.
+	failureBackoffMax = time.Hour
+)
+
+// backoffRemaining returns how much longer sync should wait before retrying
+// issuance for crt, based on the number of consecutive failures recorded on
+// its status and the time of the last one. It returns zero if crt is not
+// currently backing off.
+func backoffRemaining(crt *v1alpha1.Certificate) time.Duration {
+	if crt.Status.LastFailureTime == nil {
+		return 0
+	}
+
+	// FailedIssuanceAttempts counts the failure that just happened, so the
+	// exponent is attempts-1: the first failure backs off by exactly
+	// failureBackoffBase, the second by 2x, and so on.
+	exponent := crt.Status.FailedIssuanceAttempts - 1
+	if exponent < 0 {
+		exponent = 0
+	}
+
+	backoff := failureBackoffBase * time.Duration(math.Pow(2, float64(exponent)))
+	if backoff > failureBackoffMax {
+		backoff = failureBackoffMax
+	}
+
+	elapsed := time.Since(crt.Status.LastFailureTime.Time)
+	if elapsed >= backoff {
+		return 0
+	}
+
+	return backoff - elapsed
+}
+
+// markFailure records an issuance/renewal failure on crt's status, emits a
+// corresponding Event, and persists the change via the cert-manager client.
+func markFailure(ctx *controller.Context, crt *v1alpha1.Certificate, reason, message string) error {
+	now := metav1.Now()
+	crt.Status.LastFailureTime = &now
+	crt.Status.FailedIssuanceAttempts++
+
+	setCertificateCondition(crt, v1alpha1.CertificateConditionValidationFailed, v1alpha1.ConditionTrue, reason, message)
+	setCertificateCondition(crt, v1alpha1.CertificateConditionReady, v1alpha1.ConditionFalse, reason, message)
+
+	recordEvent(ctx, crt, api.EventTypeWarning, reason, message)
+
+	return updateCertificateStatus(ctx, crt)
+}
+
+// markIssuing records that crt is currently being (re-)issued.
+func markIssuing(ctx *controller.Context, crt *v1alpha1.Certificate, reason, message string) error {
+	setCertificateCondition(crt, v1alpha1.CertificateConditionIssuing, v1alpha1.ConditionTrue, reason, message)
+
+	recordEvent(ctx, crt, api.EventTypeNormal, reason, message)
+
+	return updateCertificateStatus(ctx, crt)
+}
+
+// markReady records a successful issuance/renewal of cert on crt's status,
+// clearing any previously recorded failures.
+func markReady(ctx *controller.Context, crt *v1alpha1.Certificate, cert *x509.Certificate) error {
+	notBefore := metav1.NewTime(cert.NotBefore)
+	notAfter := metav1.NewTime(cert.NotAfter)
+	crt.Status.NotBefore = &notBefore
+	crt.Status.NotAfter = &notAfter
+	crt.Status.LastFailureTime = nil
+	crt.Status.FailedIssuanceAttempts = 0
+
+	setCertificateCondition(crt, v1alpha1.CertificateConditionReady, v1alpha1.ConditionTrue, reasonIssued, "Certificate issued successfully")
+	setCertificateCondition(crt, v1alpha1.CertificateConditionIssuing, v1alpha1.ConditionFalse, reasonIssued, "Certificate issued successfully")
+	setCertificateCondition(crt, v1alpha1.CertificateConditionValidationFailed, v1alpha1.ConditionFalse, reasonIssued, "Certificate issued successfully")
+
+	recordEvent(ctx, crt, api.EventTypeNormal, reasonIssued, "Certificate issued successfully")
+
+	return updateCertificateStatus(ctx, crt)
+}
+
+// setCertificateCondition updates crt's condition of the given type,
+// setting LastTransitionTime only if the status actually changed, following
+// the standard Kubernetes condition convention.
+func setCertificateCondition(crt *v1alpha1.Certificate, conditionType v1alpha1.CertificateConditionType, status v1alpha1.ConditionStatus, reason, message string) {
+	now := metav1.Now()
+
+	for i, c := range crt.Status.Conditions {
+		if c.Type != conditionType {
+			continue
+		}
+
+		if c.Status != status {
+			crt.Status.Conditions[i].LastTransitionTime = &now
+		}
+		crt.Status.Conditions[i].Status = status
+		crt.Status.Conditions[i].Reason = reason
+		crt.Status.Conditions[i].Message = message
+		return
+	}
+
+	crt.Status.Conditions = append(crt.Status.Conditions, v1alpha1.CertificateCondition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: &now,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func recordEvent(ctx *controller.Context, crt *v1alpha1.Certificate, eventType, reason, message string) {
+	if ctx.Recorder == nil {
+		return
+	}
+	ctx.Recorder.Event(crt, eventType, reason, message)
+}
+
+func updateCertificateStatus(ctx *controller.Context, crt *v1alpha1.Certificate) error {
+	if ctx.CMClient == nil {
+		return nil
+	}
+	_, err := ctx.CMClient.CertmanagerV1alpha1().Certificates(crt.Namespace).UpdateStatus(crt)
+	return err
+}
@@ -3,40 +3,74 @@ package certificates
 import (
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
+	"math/rand"
 	"sort"
+	"time"
 
 	api "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha1"
 	"github.com/jetstack/cert-manager/pkg/controller"
 	"github.com/jetstack/cert-manager/pkg/issuer"
+	"github.com/jetstack/cert-manager/pkg/util/pki"
 )
 
+// defaultRenewBefore is the default amount of time before a certificate's
+// expiry that it will be renewed, if spec.renewBefore is not set.
+const defaultRenewBefore = time.Hour * 24 * 30
+
+// renewJitterFraction is the maximum fraction of the time remaining until a
+// certificate's scheduled renewal that will be added as random jitter, so
+// that a large fleet of certificates sharing a renewal time don't all hit
+// the issuer at the same instant.
+const renewJitterFraction = 0.1
+
 func sync(ctx *controller.Context, crt *v1alpha1.Certificate) error {
+	// crt comes straight from the informer cache, which must never be
+	// mutated; take a deep copy before any of the status updates below
+	// touch it.
+	crt = crt.DeepCopy()
+
+	// if we've failed recently, back off rather than hammering a broken
+	// issuer configuration again straight away
+	if d := backoffRemaining(crt); d > 0 {
+		ctx.Logger.Printf("backing off certificate '%s/%s' for %s after %d consecutive failures", crt.Namespace, crt.Name, d, crt.Status.FailedIssuanceAttempts)
+		requeueCertificateAt(ctx, crt, time.Now().Add(d))
+		return nil
+	}
+
 	// step zero: check if the referenced issuer exists and is ready
 	issuerObj, err := ctx.CertManagerInformerFactory.Certmanager().V1alpha1().Issuers().Lister().Issuers(crt.Namespace).Get(crt.Spec.Issuer)
 
 	if err != nil {
-		return fmt.Errorf("issuer '%s' for certificate '%s' does not exist", crt.Spec.Issuer, crt.Name)
+		msg := fmt.Sprintf("issuer '%s' for certificate '%s' does not exist", crt.Spec.Issuer, crt.Name)
+		markFailure(ctx, crt, reasonIssuerNotReady, msg)
+		return errors.New(msg)
 	}
 
 	if !issuerObj.Status.Ready {
-		return fmt.Errorf("issuer '%s/%s' for certificate '%s' not ready", issuerObj.Namespace, issuerObj.Name, crt.Name)
+		msg := fmt.Sprintf("issuer '%s/%s' for certificate '%s' not ready", issuerObj.Namespace, issuerObj.Name, crt.Name)
+		markFailure(ctx, crt, reasonIssuerNotReady, msg)
+		return errors.New(msg)
 	}
 
 	i, err := issuer.IssuerFor(*ctx, issuerObj)
 
 	if err != nil {
-		return fmt.Errorf("error getting issuer implementation for issuer '%s': %s", issuerObj.Name, err.Error())
+		msg := fmt.Sprintf("error getting issuer implementation for issuer '%s': %s", issuerObj.Name, err.Error())
+		markFailure(ctx, crt, reasonIssuerNotReady, msg)
+		return errors.New(msg)
 	}
 
 	err = i.Prepare(crt)
 
 	if err != nil {
-		return err
+		msg := fmt.Sprintf("error preparing issuer for certificate '%s': %s", crt.Name, err.Error())
+		markFailure(ctx, crt, reasonPrepareFailed, msg)
+		return errors.New(msg)
 	}
 
 	// step one: check if referenced secret exists, if not, trigger issue event
@@ -44,7 +78,16 @@ func sync(ctx *controller.Context, crt *v1alpha1.Certificate) error {
 
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
-			return issue(ctx, i, crt)
+			// the target secret is missing, but if a backup exists we can
+			// restore from it instead of burning an issuance
+			backup, backupErr := ctx.InformerFactory.Core().V1().Secrets().Lister().Secrets(crt.Namespace).Get(backupSecretName(crt))
+			if backupErr == nil {
+				return restoreCertificateFromBackup(ctx, crt, backup)
+			}
+			if !k8sErrors.IsNotFound(backupErr) {
+				return backupErr
+			}
+			return issue(ctx, i, crt, nil)
 		}
 		return err
 	}
@@ -54,121 +97,195 @@ func sync(ctx *controller.Context, crt *v1alpha1.Certificate) error {
 
 	// check if the certificate and private key exist, if not, trigger an issue
 	if !okcert || !okkey {
-		return issue(ctx, i, crt)
+		return issue(ctx, i, crt, nil)
 	}
 	// decode the tls certificate pem
 	block, _ := pem.Decode(certBytes)
 	if block == nil {
 		ctx.Logger.Printf("error decoding cert PEM block in '%s'", crt.Spec.SecretName)
-		return issue(ctx, i, crt)
+		return issue(ctx, i, crt, nil)
 	}
 	// parse the tls certificate
 	cert, err := x509.ParseCertificate(block.Bytes)
 	if err != nil {
 		ctx.Logger.Printf("error parsing TLS certificate in '%s': %s", crt.Spec.SecretName, err.Error())
-		return issue(ctx, i, crt)
+		return issue(ctx, i, crt, nil)
 	}
 	// decode the private key pem
 	block, _ = pem.Decode(keyBytes)
 	if block == nil {
 		ctx.Logger.Printf("error decoding private key PEM block in '%s'", crt.Spec.SecretName)
-		return issue(ctx, i, crt)
+		return issue(ctx, i, crt, nil)
 	}
-	// parse the private key
-	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
-	if err != nil {
-		ctx.Logger.Printf("error parsing private key in '%s': %s", crt.Spec.SecretName, err.Error())
-		return issue(ctx, i, crt)
+	// parse the private key, trying PKCS1, SEC1 (EC) and PKCS8 in turn so
+	// that ECDSA and PKCS8-encoded keys don't trigger a spurious re-issuance
+	if _, err = pki.DecodePrivateKeyBytes(block.Bytes); err != nil {
+		ctx.Logger.Printf("private key failed to parse in '%s': %s", crt.Spec.SecretName, err.Error())
+		return issue(ctx, i, crt, nil)
 	}
-	// validate the private key
-	if err = key.Validate(); err != nil {
-		ctx.Logger.Printf("private key failed validation in '%s': %s", crt.Spec.SecretName, err.Error())
-		return issue(ctx, i, crt)
+	// step two: check if referenced secret is still valid for the Certificate
+	// spec. if not, trigger a re-issue.
+	if ok, reason := certificateMatchesSpec(crt, cert); !ok {
+		ctx.Logger.Print(reason)
+		markFailure(ctx, crt, reasonDomainMismatch, reason)
+		return issue(ctx, i, crt, existingKeyForReuse(crt, keyBytes))
 	}
-	// step two: check if referenced secret is valid for listed domains. if not, return failure
-	if !equalUnsorted(crt.Spec.Domains, cert.DNSNames) {
-		ctx.Logger.Printf("list of domains on certificate do not match domains in spec")
-		return issue(ctx, i, crt)
+
+	// step three: check if the certificate is inside its renewal window. If
+	// it is, renew it now; otherwise schedule a renewal for later.
+	renewBefore := renewBeforeForCertificate(crt)
+	renewalTime := cert.NotAfter.Add(-renewBefore)
+
+	if time.Now().After(renewalTime) {
+		return renew(ctx, i, crt, certBytes, existingKeyForReuse(crt, keyBytes))
 	}
-	// step three: check if referenced secret is valid (after start & before expiry)
-	// if time.Now().Sub(cert.NotAfter) > time.Hour*(24*30) {
-	// 	return c.renew(crt)
-	// }
+
+	recordEvent(ctx, crt, api.EventTypeNormal, reasonRenewalScheduled, fmt.Sprintf("renewal scheduled at %s", renewalTime.Format(time.RFC3339)))
+	requeueCertificateAt(ctx, crt, renewalTime)
 
 	return nil
 }
 
+// renewBeforeForCertificate returns the configured spec.renewBefore for crt,
+// falling back to defaultRenewBefore if unset.
+func renewBeforeForCertificate(crt *v1alpha1.Certificate) time.Duration {
+	if crt.Spec.RenewBefore != nil {
+		return crt.Spec.RenewBefore.Duration
+	}
+	return defaultRenewBefore
+}
+
+// requeueCertificateAt schedules crt to be resynced at renewalTime, plus a
+// small amount of random jitter so that certificates sharing a renewal time
+// don't all trigger a renewal in the same instant.
+func requeueCertificateAt(ctx *controller.Context, crt *v1alpha1.Certificate, renewalTime time.Time) {
+	if ctx.ScheduledWorkQueue == nil {
+		return
+	}
+
+	key, err := keyFunc(crt)
+	if err != nil {
+		ctx.Logger.Printf("error computing key for certificate '%s/%s': %s", crt.Namespace, crt.Name, err.Error())
+		return
+	}
+
+	delay := time.Until(renewalTime)
+	if delay < 0 {
+		delay = 0
+	}
+	delay += jitter(delay)
+
+	ctx.ScheduledWorkQueue.Add(key, delay)
+}
+
+// jitter returns a random duration in the range [0, d*renewJitterFraction).
+func jitter(d time.Duration) time.Duration {
+	max := int64(float64(d) * renewJitterFraction)
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(max))
+}
+
+func keyFunc(crt *v1alpha1.Certificate) (string, error) {
+	if crt.Namespace == "" {
+		return crt.Name, nil
+	}
+	return crt.Namespace + "/" + crt.Name, nil
+}
+
+// existingKeyForReuse returns keyBytes if crt is configured to reuse its
+// private key across issuances, or nil otherwise, so that the issuer
+// generates a fresh key when reuse isn't requested.
+func existingKeyForReuse(crt *v1alpha1.Certificate, keyBytes []byte) []byte {
+	if !crt.Spec.ReusePrivateKey {
+		return nil
+	}
+	return keyBytes
+}
+
 // issue will attempt to retrieve a certificate from the specified issuer, or
 // return an error on failure. If retrieval is succesful, the certificate data
-// and private key will be stored in the named secret
-func issue(ctx *controller.Context, issuer issuer.Interface, crt *v1alpha1.Certificate) error {
-	cert, key, err := issuer.Issue(crt)
+// and private key will be stored in the named secret. If existingKey is
+// non-nil, the issuer will be asked to generate the CSR from it instead of
+// creating a new private key.
+func issue(ctx *controller.Context, issuer issuer.Interface, crt *v1alpha1.Certificate, existingKey []byte) error {
+	markIssuing(ctx, crt, "Issuing", "Issuing certificate")
+
+	cert, key, ca, err := issuer.Issue(crt, existingKey)
 	if err != nil {
-		return fmt.Errorf("error issuing certificate: %s", err.Error())
+		msg := fmt.Sprintf("error issuing certificate: %s", err.Error())
+		markFailure(ctx, crt, reasonIssuanceFailed, msg)
+		return errors.New(msg)
 	}
 
-	// TODO: support updating resources
-	_, err = ctx.Client.Secrets(crt.Namespace).Create(&api.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      crt.Spec.SecretName,
-			Namespace: crt.Namespace,
-		},
-		Data: map[string][]byte{
-			api.TLSCertKey:       cert,
-			api.TLSPrivateKeyKey: key,
-		},
-	})
+	if err := saveCertificate(ctx, crt, cert, key, ca); err != nil {
+		msg := fmt.Sprintf("error saving certificate: %s", err.Error())
+		markFailure(ctx, crt, reasonIssuanceFailed, msg)
+		return errors.New(msg)
+	}
 
+	parsed, err := parseLeafCertificate(cert)
 	if err != nil {
-		return fmt.Errorf("error saving certificate: %s", err.Error())
+		return fmt.Errorf("error parsing issued certificate: %s", err.Error())
 	}
 
-	return nil
+	return markReady(ctx, crt, parsed)
+}
+
+// renew will attempt to renew a certificate from the specified issuer,
+// reusing the existing private key if crt.Spec.ReusePrivateKey is set, and
+// store the result in the named secret.
+func renew(ctx *controller.Context, i issuer.Interface, crt *v1alpha1.Certificate, existingCert, existingKey []byte) error {
+	markIssuing(ctx, crt, "Renewing", "Renewing certificate")
+
+	cert, key, ca, err := i.Renew(crt, existingCert, existingKey)
+	if err != nil {
+		msg := fmt.Sprintf("error renewing certificate: %s", err.Error())
+		markFailure(ctx, crt, reasonIssuanceFailed, msg)
+		return errors.New(msg)
+	}
+
+	if err := saveCertificate(ctx, crt, cert, key, ca); err != nil {
+		msg := fmt.Sprintf("error saving renewed certificate: %s", err.Error())
+		markFailure(ctx, crt, reasonIssuanceFailed, msg)
+		return errors.New(msg)
+	}
+
+	parsed, err := parseLeafCertificate(cert)
+	if err != nil {
+		return fmt.Errorf("error parsing renewed certificate: %s", err.Error())
+	}
+
+	return markReady(ctx, crt, parsed)
 }
 
-// // renew will attempt to renew a certificate from the specified issuer, or
-// // return an error on failure. If renewal is succesful, the certificate data
-// // and private key will be stored in the named secret
-// func (c *Controller) renew(crt *v1alpha1.Certificate) error {
-// 	i, err := issuer.IssuerFor(crt)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	cert, key, err := i.Renew(&ctx, crt)
-// 	if err != nil {
-// 		return fmt.Errorf("error renewing certificate: %s", err.Error())
-// 	}
-
-// 	_, err = ctx.Client.Secrets(crt.Namespace).Update(&api.Secret{
-// 		ObjectMeta: metav1.ObjectMeta{
-// 			Name:      crt.Spec.SecretName,
-// 			Namespace: crt.Namespace,
-// 		},
-// 		Data: map[string][]byte{
-// 			api.TLSCertKey:       cert,
-// 			api.TLSPrivateKeyKey: key,
-// 		},
-// 	})
-
-// 	if err != nil {
-// 		return fmt.Errorf("error saving certificate: %s", err.Error())
-// 	}
-
-// 	return nil
-// }
-
-func equalUnsorted(s1 []string, s2 []string) bool {
+// parseLeafCertificate parses the PEM-encoded leaf certificate returned by
+// an issuer, as stored under tls.crt.
+func parseLeafCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode certificate PEM")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// equalUnsorted reports whether s1 and s2 contain the same strings,
+// ignoring order. Neither input slice is mutated.
+func equalUnsorted(s1, s2 []string) bool {
 	if len(s1) != len(s2) {
 		return false
 	}
-	s1_2, s2_2 := make([]string, len(s1)), make([]string, len(s2))
-	sort.Strings(s1)
-	sort.Strings(s2)
-	for i, s := range s1_2 {
-		if s != s2_2[i] {
+
+	a := append([]string(nil), s1...)
+	b := append([]string(nil), s2...)
+	sort.Strings(a)
+	sort.Strings(b)
+
+	for i := range a {
+		if a[i] != b[i] {
 			return false
 		}
 	}
 	return true
-}
\ No newline at end of file
+}
@@ -0,0 +1,34 @@
+package certificates
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitter(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+	}{
+		{"zero duration", 0},
+		{"negative duration", -time.Second},
+		{"sub-nanosecond fraction truncates to zero", 5 * time.Nanosecond},
+		{"one second", time.Second},
+		{"thirty days", 30 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				got := jitter(tt.d)
+				if got < 0 {
+					t.Fatalf("jitter(%s) = %s, want >= 0", tt.d, got)
+				}
+				max := time.Duration(float64(tt.d) * renewJitterFraction)
+				if got >= max && max > 0 {
+					t.Fatalf("jitter(%s) = %s, want < %s", tt.d, got, max)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"log"
+
+	informers "k8s.io/client-go/informers"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	cmclientset "github.com/jetstack/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
+)
+
+// Context holds the clients, informers and shared state that controllers
+// need in order to reconcile cert-manager resources.
+type Context struct {
+	Client                     corev1.CoreV1Interface
+	CMClient                   cmclientset.Interface
+	InformerFactory            informers.SharedInformerFactory
+	CertManagerInformerFactory cminformers.SharedInformerFactory
+	Logger                     *log.Logger
+	Recorder                   record.EventRecorder
+
+	// ScheduledWorkQueue allows a controller to requeue a resource key for
+	// processing after a delay, e.g. to trigger renewal at a Certificate's
+	// scheduled renewal time.
+	ScheduledWorkQueue ScheduledWorkQueue
+}
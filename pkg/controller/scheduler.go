@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// ScheduledWorkQueue allows a key to be added to a workqueue after a delay
+// has elapsed, so controllers can schedule themselves to be woken up again
+// at a future point in time (e.g. a Certificate's renewal time) rather than
+// relying solely on informer events.
+type ScheduledWorkQueue interface {
+	// Add schedules key to be added to the underlying queue after duration
+	// has elapsed. Adding the same key again before it fires will reset the
+	// timer.
+	Add(key string, duration time.Duration)
+}
+
+type scheduledWorkQueue struct {
+	queue workqueue.Interface
+
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+}
+
+// NewScheduledWorkQueue returns a new ScheduledWorkQueue that adds keys to
+// queue after their scheduled delay has elapsed.
+func NewScheduledWorkQueue(queue workqueue.Interface) ScheduledWorkQueue {
+	return &scheduledWorkQueue{
+		queue:  queue,
+		timers: make(map[string]*time.Timer),
+	}
+}
+
+func (s *scheduledWorkQueue) Add(key string, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.timers[key]; ok {
+		existing.Stop()
+	}
+
+	s.timers[key] = time.AfterFunc(duration, func() {
+		s.mu.Lock()
+		delete(s.timers, key)
+		s.mu.Unlock()
+
+		s.queue.Add(key)
+	})
+}
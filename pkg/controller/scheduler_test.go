@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeQueue is a minimal workqueue.Interface that records the items passed
+// to Add.
+type fakeQueue struct {
+	mu    sync.Mutex
+	added []interface{}
+}
+
+func (f *fakeQueue) Add(item interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.added = append(f.added, item)
+}
+
+func (f *fakeQueue) addedItems() []interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]interface{}, len(f.added))
+	copy(out, f.added)
+	return out
+}
+
+func (f *fakeQueue) Len() int                 { return 0 }
+func (f *fakeQueue) Get() (interface{}, bool) { return nil, false }
+func (f *fakeQueue) Done(item interface{})    {}
+func (f *fakeQueue) ShutDown()                {}
+func (f *fakeQueue) ShuttingDown() bool       { return false }
+
+func TestScheduledWorkQueueAddResetsExistingTimer(t *testing.T) {
+	queue := &fakeQueue{}
+	swq := NewScheduledWorkQueue(queue)
+
+	// schedule "key" to fire a long way off, then immediately reschedule it
+	// to fire very soon. If Add doesn't reset the first timer, "key" ends up
+	// on the queue twice.
+	swq.Add("key", 200*time.Millisecond)
+	swq.Add("key", 10*time.Millisecond)
+
+	time.Sleep(300 * time.Millisecond)
+
+	added := queue.addedItems()
+	if len(added) != 1 {
+		t.Fatalf("got %d items added to queue, want 1 (rescheduling should reset the existing timer): %v", len(added), added)
+	}
+	if added[0] != "key" {
+		t.Errorf("got item %v, want %q", added[0], "key")
+	}
+}
+
+func TestScheduledWorkQueueAddDistinctKeysBothFire(t *testing.T) {
+	queue := &fakeQueue{}
+	swq := NewScheduledWorkQueue(queue)
+
+	swq.Add("a", 10*time.Millisecond)
+	swq.Add("b", 10*time.Millisecond)
+
+	time.Sleep(100 * time.Millisecond)
+
+	added := queue.addedItems()
+	if len(added) != 2 {
+		t.Fatalf("got %d items added to queue, want 2: %v", len(added), added)
+	}
+}
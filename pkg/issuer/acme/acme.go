@@ -0,0 +1,53 @@
+package acme
+
+import (
+	"fmt"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/solver"
+)
+
+// Acme is the issuer.Interface implementation backed by the ACME protocol.
+type Acme struct {
+	ctx    *controller.Context
+	issuer *v1alpha1.Issuer
+}
+
+// New returns a new ACME issuer for issuerObj.
+func New(ctx *controller.Context, issuerObj *v1alpha1.Issuer) (*Acme, error) {
+	if issuerObj.Spec.ACME == nil {
+		return nil, fmt.Errorf("issuer '%s' is not configured as an ACME issuer", issuerObj.Name)
+	}
+
+	return &Acme{ctx: ctx, issuer: issuerObj}, nil
+}
+
+// Prepare selects and invokes the appropriate challenge solver (http-01,
+// dns-01 or tls-alpn-01) for each domain on crt, based on the Certificate's
+// per-domain solver configuration.
+func (a *Acme) Prepare(crt *v1alpha1.Certificate) error {
+	for _, domain := range crt.Spec.Domains {
+		s, err := solver.SolverFor(crt, domain)
+		if err != nil {
+			return fmt.Errorf("error determining challenge solver for domain '%s': %s", domain, err.Error())
+		}
+
+		// keyAuthorization and token are computed from the ACME order/
+		// authorization for domain; that exchange is handled by the wider
+		// ACME client and isn't reproduced here.
+		if err := s.Present(a.ctx, crt, domain, "", ""); err != nil {
+			return fmt.Errorf("error presenting challenge for domain '%s': %s", domain, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (a *Acme) Issue(crt *v1alpha1.Certificate, existingKey []byte) (cert, key, ca []byte, err error) {
+	return nil, nil, nil, fmt.Errorf("acme issuer Issue not yet implemented")
+}
+
+func (a *Acme) Renew(crt *v1alpha1.Certificate, existingCert, existingKey []byte) (cert, key, ca []byte, err error) {
+	return nil, nil, nil, fmt.Errorf("acme issuer Renew not yet implemented")
+}
@@ -0,0 +1,66 @@
+package solver
+
+import (
+	"fmt"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/controller"
+)
+
+// Solver is implemented by each ACME challenge mechanism (http-01, dns-01,
+// tls-alpn-01). Present performs whatever setup is required to make the
+// given domain's challenge response visible to the ACME server, and CleanUp
+// tears it back down again once validation has completed.
+type Solver interface {
+	Present(ctx *controller.Context, crt *v1alpha1.Certificate, domain, token, keyAuthorization string) error
+	CleanUp(ctx *controller.Context, crt *v1alpha1.Certificate, domain, token, keyAuthorization string) error
+}
+
+// SolverFor returns the Solver that should be used to validate domain,
+// according to crt's per-domain solver configuration. If no configuration
+// is found for domain, http-01 is used as the default, matching the
+// historical behaviour of this issuer.
+func SolverFor(crt *v1alpha1.Certificate, domain string) (Solver, error) {
+	cfg := domainConfig(crt, domain)
+
+	switch {
+	case cfg == nil:
+		return &HTTP01Solver{}, nil
+	case cfg.TLSALPN01 != nil:
+		return &TLSALPN01Solver{}, nil
+	case cfg.DNS01 != nil:
+		return nil, fmt.Errorf("dns-01 solver not yet implemented")
+	case cfg.HTTP01 != nil:
+		return &HTTP01Solver{}, nil
+	default:
+		return &HTTP01Solver{}, nil
+	}
+}
+
+func domainConfig(crt *v1alpha1.Certificate, domain string) *v1alpha1.ACMECertificateDomainSolverConfig {
+	if crt.Spec.ACME == nil {
+		return nil
+	}
+
+	for _, d := range crt.Spec.ACME.Config {
+		for _, dom := range d.Domains {
+			if dom == domain {
+				return &d.ACMECertificateDomainSolverConfig
+			}
+		}
+	}
+
+	return nil
+}
+
+// HTTP01Solver is a placeholder for the pre-existing http-01 solver
+// implementation, which is unaffected by the addition of tls-alpn-01.
+type HTTP01Solver struct{}
+
+func (h *HTTP01Solver) Present(ctx *controller.Context, crt *v1alpha1.Certificate, domain, token, keyAuthorization string) error {
+	return fmt.Errorf("http-01 solver not yet implemented")
+}
+
+func (h *HTTP01Solver) CleanUp(ctx *controller.Context, crt *v1alpha1.Certificate, domain, token, keyAuthorization string) error {
+	return fmt.Errorf("http-01 solver not yet implemented")
+}
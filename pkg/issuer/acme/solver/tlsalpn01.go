@@ -0,0 +1,240 @@
+package solver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	api "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/controller"
+)
+
+// acmeTLS1Protocol is the ALPN protocol name a ClientHello must advertise for
+// it to be served the tls-alpn-01 challenge certificate, per RFC 8737.
+const acmeTLS1Protocol = "acme-tls/1"
+
+// idPeACMEIdentifier is the OID of the acmeIdentifier X.509 extension that
+// must be present, and marked critical, in the tls-alpn-01 challenge
+// certificate.
+var idPeACMEIdentifier = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 30, 1}
+
+// TLSALPN01Solver solves ACME tls-alpn-01 challenges by serving a
+// self-signed certificate containing the acmeIdentifier extension from an
+// in-cluster Pod/Service listening on port 443, which only responds to
+// ClientHellos advertising the "acme-tls/1" ALPN protocol.
+type TLSALPN01Solver struct{}
+
+func (s *TLSALPN01Solver) Present(ctx *controller.Context, crt *v1alpha1.Certificate, domain, token, keyAuthorization string) error {
+	cert, key, err := challengeCertificate(domain, keyAuthorization)
+	if err != nil {
+		return fmt.Errorf("error generating tls-alpn-01 challenge certificate: %s", err.Error())
+	}
+
+	name := solverResourceName(crt, domain)
+
+	secret, err := ctx.Client.Secrets(crt.Namespace).Create(&api.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: crt.Namespace,
+			Labels:    solverLabels(name),
+		},
+		Data: map[string][]byte{
+			api.TLSCertKey:       cert,
+			api.TLSPrivateKeyKey: key,
+		},
+		Type: api.SecretTypeTLS,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating tls-alpn-01 challenge secret: %s", err.Error())
+	}
+
+	_, err = ctx.Client.Pods(crt.Namespace).Create(solverPod(name, secret.Name))
+	if err != nil {
+		if delErr := ctx.Client.Secrets(crt.Namespace).Delete(name, nil); delErr != nil && !k8sErrors.IsNotFound(delErr) {
+			return fmt.Errorf("error creating tls-alpn-01 solver pod: %s (and error cleaning up challenge secret: %s)", err.Error(), delErr.Error())
+		}
+		return fmt.Errorf("error creating tls-alpn-01 solver pod: %s", err.Error())
+	}
+
+	_, err = ctx.Client.Services(crt.Namespace).Create(solverService(name))
+	if err != nil {
+		var cleanupErrs []string
+		if delErr := ctx.Client.Pods(crt.Namespace).Delete(name, nil); delErr != nil && !k8sErrors.IsNotFound(delErr) {
+			cleanupErrs = append(cleanupErrs, delErr.Error())
+		}
+		if delErr := ctx.Client.Secrets(crt.Namespace).Delete(name, nil); delErr != nil && !k8sErrors.IsNotFound(delErr) {
+			cleanupErrs = append(cleanupErrs, delErr.Error())
+		}
+		if len(cleanupErrs) > 0 {
+			return fmt.Errorf("error creating tls-alpn-01 solver service: %s (and error cleaning up: %s)", err.Error(), strings.Join(cleanupErrs, "; "))
+		}
+		return fmt.Errorf("error creating tls-alpn-01 solver service: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (s *TLSALPN01Solver) CleanUp(ctx *controller.Context, crt *v1alpha1.Certificate, domain, token, keyAuthorization string) error {
+	name := solverResourceName(crt, domain)
+
+	var errs []string
+	if err := ctx.Client.Services(crt.Namespace).Delete(name, nil); err != nil && !k8sErrors.IsNotFound(err) {
+		errs = append(errs, fmt.Sprintf("error cleaning up tls-alpn-01 solver service: %s", err.Error()))
+	}
+	if err := ctx.Client.Pods(crt.Namespace).Delete(name, nil); err != nil && !k8sErrors.IsNotFound(err) {
+		errs = append(errs, fmt.Sprintf("error cleaning up tls-alpn-01 solver pod: %s", err.Error()))
+	}
+	if err := ctx.Client.Secrets(crt.Namespace).Delete(name, nil); err != nil && !k8sErrors.IsNotFound(err) {
+		errs = append(errs, fmt.Sprintf("error cleaning up tls-alpn-01 solver secret: %s", err.Error()))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// challengeCertificate builds a self-signed certificate and private key for
+// domain, carrying the critical acmeIdentifier extension whose value is the
+// DER-encoded OCTET STRING of SHA-256(keyAuthorization), as required by the
+// tls-alpn-01 challenge (RFC 8737).
+func challengeCertificate(domain, keyAuthorization string) (cert, key []byte, err error) {
+	sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	digest := sha256.Sum256([]byte(keyAuthorization))
+	extValue, err := asn1.Marshal(digest[:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: domain},
+		DNSNames:     []string{domain},
+		ExtraExtensions: []pkix.Extension{
+			{
+				Id:       idPeACMEIdentifier,
+				Critical: true,
+				Value:    extValue,
+			},
+		},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &sk.PublicKey, sk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM := pemEncode("CERTIFICATE", der)
+
+	keyDER, err := x509.MarshalECPrivateKey(sk)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM := pemEncode("EC PRIVATE KEY", keyDER)
+
+	return certPEM, keyPEM, nil
+}
+
+// tlsConfigForChallenge returns a *tls.Config that only completes the
+// handshake when the ClientHello advertises the acme-tls/1 ALPN protocol,
+// serving the challenge certificate in that case and rejecting all other
+// connections, per RFC 8737 section 3.
+func tlsConfigForChallenge(cert tls.Certificate) *tls.Config {
+	return &tls.Config{
+		NextProtos: []string{acmeTLS1Protocol},
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			for _, proto := range hello.SupportedProtos {
+				if proto == acmeTLS1Protocol {
+					return &tls.Config{
+						Certificates: []tls.Certificate{cert},
+						NextProtos:   []string{acmeTLS1Protocol},
+					}, nil
+				}
+			}
+			return nil, fmt.Errorf("no acceptable ALPN protocol offered by client")
+		},
+	}
+}
+
+func pemEncode(blockType string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+// solverResourceName derives a fixed-length, DNS-1123-safe resource name for
+// the given (Certificate, domain) pair. Hashing the pair as a whole, rather
+// than concatenating crt.Name before truncating, guarantees the result fits
+// the 63-character name limit regardless of crt.Name's length and that two
+// domains on the same Certificate never collide.
+func solverResourceName(crt *v1alpha1.Certificate, domain string) string {
+	digest := sha256.Sum256([]byte(crt.Namespace + "/" + crt.Name + "/" + domain))
+	return fmt.Sprintf("cm-acme-tls-alpn-%x", digest)[:63]
+}
+
+func solverLabels(name string) map[string]string {
+	return map[string]string{
+		"certmanager.k8s.io/acme-http-domain": name,
+	}
+}
+
+func solverPod(name, secretName string) *api.Pod {
+	return &api.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Labels:    solverLabels(name),
+		},
+		Spec: api.PodSpec{
+			Containers: []api.Container{
+				{
+					Name:  "acmesolver",
+					Image: "quay.io/jetstack/cert-manager-acmesolver:latest",
+					Args: []string{
+						"--tls-alpn-01",
+						"--secret-name=" + secretName,
+					},
+					Ports: []api.ContainerPort{
+						{ContainerPort: 443},
+					},
+				},
+			},
+		},
+	}
+}
+
+func solverService(name string) *api.Service {
+	return &api.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: solverLabels(name),
+		},
+		Spec: api.ServiceSpec{
+			Selector: solverLabels(name),
+			Ports: []api.ServicePort{
+				{
+					Name:       "https",
+					Port:       443,
+					TargetPort: intstr.FromInt(443),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package issuer
+
+import (
+	"fmt"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha1"
+	"github.com/jetstack/cert-manager/pkg/controller"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme"
+)
+
+// Interface is implemented by each issuer type (ACME, CA, Vault, ...) and is
+// responsible for preparing, issuing and renewing certificates on behalf of
+// a Certificate resource.
+type Interface interface {
+	// Prepare prepares the issuer to issue the given Certificate, for
+	// example by ensuring any ACME challenge solvers are set up.
+	Prepare(crt *v1alpha1.Certificate) error
+
+	// Issue obtains a new certificate for crt. If existingKey is non-nil and
+	// crt.Spec.ReusePrivateKey is set, the issuer must generate its CSR from
+	// existingKey instead of generating a new private key. ca holds the
+	// issuing CA's certificate chain, if the issuer has one.
+	Issue(crt *v1alpha1.Certificate, existingKey []byte) (cert, key, ca []byte, err error)
+
+	// Renew obtains a renewed certificate for crt, re-using the private key
+	// material of an existing issuance where possible so that clients
+	// pinning the existing public key are not broken by renewal.
+	Renew(crt *v1alpha1.Certificate, existingCert, existingKey []byte) (cert, key, ca []byte, err error)
+}
+
+// IssuerFor returns the issuer implementation backing issuerObj.
+func IssuerFor(ctx controller.Context, issuerObj *v1alpha1.Issuer) (Interface, error) {
+	switch {
+	case issuerObj.Spec.ACME != nil:
+		return acme.New(&ctx, issuerObj)
+	case issuerObj.Spec.CA != nil:
+		return nil, fmt.Errorf("ca issuer not yet implemented")
+	}
+
+	return nil, fmt.Errorf("issuer '%s' does not specify a valid issuer configuration", issuerObj.Name)
+}
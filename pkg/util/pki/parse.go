@@ -0,0 +1,33 @@
+package pki
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+)
+
+// DecodePrivateKeyBytes will decode a DER encoded private key (the bytes of
+// an already PEM-decoded block) into a crypto.Signer. It supports PKCS1 and PKCS8 encoded RSA keys, and SEC1
+// (EC PRIVATE KEY) and PKCS8 encoded ECDSA keys, trying each format in turn
+// so that callers don't need to know the key type ahead of time.
+func DecodePrivateKeyBytes(keyBytes []byte) (crypto.Signer, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(keyBytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParseECPrivateKey(keyBytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key: %s", err.Error())
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unknown private key type: %T", key)
+	}
+
+	return signer, nil
+}